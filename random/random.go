@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
 	"strconv"
@@ -22,21 +23,128 @@ type Distribution interface {
 	Generate() int64
 }
 
+// Ranged is implemented by distributions with a well-defined [Min, Max]
+// support. It's what Inverted needs to mirror sampled values around the
+// interval's midpoint.
+type Ranged interface {
+	Distribution
+	Bounds() (min, max int64)
+}
+
 var (
 	_ Distribution  = (*Fixed)(nil)
 	_ Distribution  = (*Uniform)(nil)
+	_ Distribution  = (*Gaussian)(nil)
+	_ Distribution  = (*Exp)(nil)
+	_ Distribution  = (*Extreme)(nil)
+	_ Distribution  = (*Zipf)(nil)
+	_ Distribution  = (*Hotspot)(nil)
+	_ Distribution  = (*Weighted)(nil)
 	_ Distribution  = (*Ratio)(nil)
+	_ Distribution  = Inverted{}
+	_ Ranged        = Uniform{}
+	_ Ranged        = Gaussian{}
+	_ Ranged        = Exp{}
+	_ Ranged        = Extreme{}
+	_ Ranged        = Zipf{}
+	_ Ranged        = Hotspot{}
 	_ rand.Source64 = (*lockedSource)(nil)
+	_ rand.Source64 = (*xoshiro256pp)(nil)
 )
 
 // globalRand is a copy of the rand.globalRand (git.io/fA2Ls) for
-// usermode package's use only.
+// usermode package's use only. It is seeded from wall-clock time by
+// default; call SeedGlobal to make it (and any Distribution that doesn't
+// carry its own Rand) produce a reproducible sequence.
 var globalRand = rand.New(&lockedSource{
 	src: rand.NewSource(
 		time.Now().UnixNano() + int64(os.Getpid()),
 	).(rand.Source64),
 })
 
+// SeedGlobal reseeds the package-level random source deterministically.
+// It is meant to be wired up to a CLI/env knob (e.g. "-random-seed") so
+// that a whole benchmark run becomes reproducible; distributions that
+// were constructed with their own Rand (see NewSource) are unaffected.
+func SeedGlobal(seed uint64) {
+	globalRand.Seed(int64(seed))
+}
+
+// NewSource returns a fast, non-cryptographic rand.Source64 seeded
+// deterministically from seed, implementing xoshiro256++ (Blackman &
+// Vigna). Unlike globalRand it isn't guarded by a mutex, so it is meant
+// to be used as the dedicated Source of a single goroutine's *rand.Rand -
+// for example each worker can get its own collision-free Source via
+// NewSource(SplitMix64(masterSeed, workerID)).
+func NewSource(seed uint64) rand.Source64 {
+	var s xoshiro256pp
+	sm := seed
+	for i := range s.s {
+		sm += 0x9E3779B97F4A7C15
+		s.s[i] = splitMix64(sm)
+	}
+	return &s
+}
+
+// SplitMix64 derives a fast, well-distributed 64-bit value from seed and
+// n, suitable for deriving N independent, uncorrelated sub-seeds (e.g.
+// one per worker goroutine) from a single master seed.
+func SplitMix64(seed uint64, n uint64) uint64 {
+	return splitMix64(seed + n*0x9E3779B97F4A7C15)
+}
+
+// rnd returns r if non-nil, falling back to the shared globalRand
+// otherwise. It's how Distribution implementations pick between a
+// caller-supplied *rand.Rand and the package-level default.
+func rnd(r *rand.Rand) *rand.Rand {
+	if r != nil {
+		return r
+	}
+	return globalRand
+}
+
+func splitMix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
+
+// xoshiro256pp is an unsynchronized xoshiro256++ pseudo-random source.
+// See https://prng.di.unimi.it/xoshiro256plusplus.c.
+type xoshiro256pp struct {
+	s [4]uint64
+}
+
+func rotl(x uint64, k uint) uint64 {
+	return (x << k) | (x >> (64 - k))
+}
+
+func (x *xoshiro256pp) Uint64() uint64 {
+	result := rotl(x.s[0]+x.s[3], 23) + x.s[0]
+
+	t := x.s[1] << 17
+	x.s[2] ^= x.s[0]
+	x.s[3] ^= x.s[1]
+	x.s[1] ^= x.s[2]
+	x.s[0] ^= x.s[3]
+	x.s[2] ^= t
+	x.s[3] = rotl(x.s[3], 45)
+
+	return result
+}
+
+func (x *xoshiro256pp) Int63() int64 {
+	return int64(x.Uint64() >> 1)
+}
+
+func (x *xoshiro256pp) Seed(seed int64) {
+	sm := uint64(seed)
+	for i := range x.s {
+		sm += 0x9E3779B97F4A7C15
+		x.s[i] = splitMix64(sm)
+	}
+}
+
 // Product gives a product of values generated by the given distributions.
 // The smallest value returned by the function is 1.
 func Product(d ...Distribution) int64 {
@@ -127,11 +235,7 @@ func (g *Generator) generateSeed(column string, d Distribution) (int64, bool) {
 // ParseDistribution parses a distribution string. See "Supported types" section
 // of a document located under the following url for more details:
 //
-//   https://cassandra.apache.org/doc/latest/tools/cassandra_stress.html#profile
-//
-// TODO(rjeczalik): Add support for inverted distributions.
-// TODO(rjeczalik): Add support for short syntax declaration (e.g. "uniform(1..1K)").
-// TODO(rjeczalik): Add support for more distribution types.
+//	https://cassandra.apache.org/doc/latest/tools/cassandra_stress.html#profile
 func ParseDistribution(s string) (Distribution, error) {
 	i := strings.IndexRune(s, '(')
 	if i == -1 || i == 0 {
@@ -142,12 +246,28 @@ func ParseDistribution(s string) (Distribution, error) {
 		return nil, errors.New("missing parameter list end delimiter ')'")
 	}
 	typ, val := s[:i], s[i+1:j]
-	if typ[0] == '~' {
-		return nil, errors.New("unsupported inverted distribution: " + typ)
+	inverted := typ[0] == '~'
+	if inverted {
+		typ = typ[1:]
 	}
+	d, err := parseDistribution(typ, val)
+	if err != nil {
+		return nil, err
+	}
+	if !inverted {
+		return d, nil
+	}
+	r, ok := d.(Ranged)
+	if !ok {
+		return nil, errors.Errorf("distribution %q does not support inversion", typ)
+	}
+	return Inverted{Ranged: r}, nil
+}
+
+func parseDistribution(typ, val string) (Distribution, error) {
 	switch typ {
 	case "fixed":
-		n, err := strconv.ParseUint(val, 10, 32)
+		n, err := parseSize(val)
 		if err != nil {
 			return nil, errors.Wrap(err, "value for fixed distribution is invalid")
 		}
@@ -155,30 +275,242 @@ func ParseDistribution(s string) (Distribution, error) {
 			Value: int64(n),
 		}, nil
 	case "uniform":
-		p := strings.Split(s[i+1:j], "..")
-		if len(p) != 2 {
-			return nil, errors.New("interval for uniform distribution has invalid format, expected: min..max")
+		min, max, _, err := parseRange(val)
+		if err != nil {
+			return nil, errors.Wrap(err, "interval for uniform distribution is invalid")
 		}
-		min, err := strconv.ParseUint(p[0], 10, 32)
+		return &Uniform{
+			Min: int64(min),
+			Max: int64(max),
+		}, nil
+	case "gaussian", "gauss":
+		min, max, extra, err := parseRange(val)
 		if err != nil {
-			return nil, errors.Wrap(err, "min parameter uniform distribution is invalid")
+			return nil, errors.Wrap(err, "interval for gaussian distribution is invalid")
 		}
-		max, err := strconv.ParseUint(p[1], 10, 32)
+		mean, stddev, err := parseGaussianParams(min, max, extra)
 		if err != nil {
-			return nil, errors.Wrap(err, "max parameter for uniform distribution is invalid")
+			return nil, err
 		}
-		if max < min {
-			return nil, errors.New("interval for uniform distribution is invalid: min >= max")
+		if mean < float64(min) || mean > float64(max) {
+			return nil, errors.New("mean parameter for gaussian distribution must be within [min, max]")
 		}
-		return &Uniform{
+		return &Gaussian{
+			Min:    int64(min),
+			Max:    int64(max),
+			Mean:   mean,
+			StdDev: stddev,
+		}, nil
+	case "exp", "exponential":
+		min, max, extra, err := parseRange(val)
+		if err != nil {
+			return nil, errors.Wrap(err, "interval for exp distribution is invalid")
+		}
+		lambda := 1.0
+		if len(extra) > 0 {
+			lambda, err = strconv.ParseFloat(extra[0], 64)
+			if err != nil {
+				return nil, errors.Wrap(err, "lambda parameter for exp distribution is invalid")
+			}
+		}
+		if lambda <= 0 {
+			return nil, errors.New("lambda parameter for exp distribution must be positive")
+		}
+		return &Exp{
+			Min:    int64(min),
+			Max:    int64(max),
+			Lambda: lambda,
+		}, nil
+	case "extreme":
+		min, max, extra, err := parseRange(val)
+		if err != nil {
+			return nil, errors.Wrap(err, "interval for extreme distribution is invalid")
+		}
+		if len(extra) != 1 {
+			return nil, errors.New("shape parameter for extreme distribution is missing")
+		}
+		shape, err := strconv.ParseFloat(extra[0], 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "shape parameter for extreme distribution is invalid")
+		}
+		if shape <= 0 {
+			return nil, errors.New("shape parameter for extreme distribution must be positive")
+		}
+		return &Extreme{
+			Min:   int64(min),
+			Max:   int64(max),
+			Shape: shape,
+		}, nil
+	case "zipf":
+		min, max, extra, err := parseRange(val)
+		if err != nil {
+			return nil, errors.Wrap(err, "interval for zipf distribution is invalid")
+		}
+		if len(extra) != 1 {
+			return nil, errors.New("s parameter for zipf distribution is missing")
+		}
+		s, err := strconv.ParseFloat(extra[0], 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "s parameter for zipf distribution is invalid")
+		}
+		return &Zipf{
 			Min: int64(min),
 			Max: int64(max),
+			S:   s,
 		}, nil
+	case "hotspot":
+		min, max, extra, err := parseRange(val)
+		if err != nil {
+			return nil, errors.Wrap(err, "interval for hotspot distribution is invalid")
+		}
+		if len(extra) != 2 {
+			return nil, errors.New("hot_fraction and hot_traffic parameters for hotspot distribution are missing")
+		}
+		hotFraction, err := strconv.ParseFloat(extra[0], 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "hot_fraction parameter for hotspot distribution is invalid")
+		}
+		hotTraffic, err := strconv.ParseFloat(extra[1], 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "hot_traffic parameter for hotspot distribution is invalid")
+		}
+		if hotFraction < 0 || hotFraction > 1 {
+			return nil, errors.New("hot_fraction parameter for hotspot distribution must be within [0, 1]")
+		}
+		if hotTraffic < 0 || hotTraffic > 1 {
+			return nil, errors.New("hot_traffic parameter for hotspot distribution must be within [0, 1]")
+		}
+		return &Hotspot{
+			Min:         int64(min),
+			Max:         int64(max),
+			HotFraction: hotFraction,
+			HotTraffic:  hotTraffic,
+		}, nil
+	case "weighted":
+		values, weights, err := parseWeighted(val)
+		if err != nil {
+			return nil, errors.Wrap(err, "value list for weighted distribution is invalid")
+		}
+		return NewWeighted(values, weights), nil
 	default:
 		return nil, errors.New("unsupported distribution: " + typ)
 	}
 }
 
+// parseWeighted parses a "[v1:w1,v2:w2,...]" value list into parallel
+// slices of values and weights.
+func parseWeighted(val string) (values []int64, weights []float64, err error) {
+	val = strings.TrimSpace(val)
+	if !strings.HasPrefix(val, "[") || !strings.HasSuffix(val, "]") {
+		return nil, nil, errors.New("value list must be enclosed in '[' and ']'")
+	}
+	for _, entry := range strings.Split(val[1:len(val)-1], ",") {
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			return nil, nil, errors.New("entry has invalid format, expected: value:weight")
+		}
+		v, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "value is invalid")
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "weight is invalid")
+		}
+		values = append(values, v)
+		weights = append(weights, w)
+	}
+	return values, weights, nil
+}
+
+// parseRange splits a "min..max" interval, optionally followed by extra
+// comma-separated parameters (e.g. "1..100,3" for a gaussian's stdvrng),
+// into its bounds and the leftover parameters.
+func parseRange(val string) (min, max uint64, extra []string, err error) {
+	p := strings.SplitN(val, "..", 2)
+	if len(p) != 2 {
+		return 0, 0, nil, errors.New("invalid format, expected: min..max")
+	}
+	min, err = parseSize(p[0])
+	if err != nil {
+		return 0, 0, nil, errors.Wrap(err, "min parameter is invalid")
+	}
+	rest := strings.Split(p[1], ",")
+	max, err = parseSize(rest[0])
+	if err != nil {
+		return 0, 0, nil, errors.Wrap(err, "max parameter is invalid")
+	}
+	if max < min {
+		return 0, 0, nil, errors.New("invalid interval: min > max")
+	}
+	return min, max, rest[1:], nil
+}
+
+// siSuffixes maps the SI and binary magnitude suffixes accepted by
+// parseSize to their multiplier. Binary suffixes ("Ki", "Mi", "Gi") are
+// matched before their decimal counterparts since they share a prefix.
+var siSuffixes = []struct {
+	suffix string
+	mult   uint64
+}{
+	{"Ki", 1 << 10},
+	{"Mi", 1 << 20},
+	{"Gi", 1 << 30},
+	{"K", 1e3},
+	{"M", 1e6},
+	{"B", 1e9},
+}
+
+// parseSize parses an unsigned integer optionally followed by an SI
+// ("K", "M", "B") or binary ("Ki", "Mi", "Gi") magnitude suffix, e.g.
+// "1K" (1,000), "1Ki" (1,024) or "1M" (1,000,000), expanding it before
+// delegating to strconv.ParseUint.
+func parseSize(s string) (uint64, error) {
+	mult := uint64(1)
+	for _, su := range siSuffixes {
+		if strings.HasSuffix(s, su.suffix) {
+			mult, s = su.mult, s[:len(s)-len(su.suffix)]
+			break
+		}
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return n * mult, nil
+}
+
+// parseGaussianParams derives the mean and standard deviation for a
+// gaussian distribution from its extra parameters, supporting both the
+// "min..max,stdvrng" and "min..max,mean,stddev" forms used by
+// cassandra-stress. With no extra parameters the distribution is centered
+// on the interval with a stdvrng of 6, i.e. [Min,Max] covers six sigma.
+func parseGaussianParams(min, max uint64, extra []string) (mean, stddev float64, err error) {
+	mean = float64(min+max) / 2
+	switch len(extra) {
+	case 0:
+		stddev = float64(max-min) / 6
+	case 1:
+		stdvrng, err := strconv.ParseFloat(extra[0], 64)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "stdvrng parameter for gaussian distribution is invalid")
+		}
+		stddev = float64(max-min) / stdvrng
+	case 2:
+		mean, err = strconv.ParseFloat(extra[0], 64)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "mean parameter for gaussian distribution is invalid")
+		}
+		stddev, err = strconv.ParseFloat(extra[1], 64)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "stddev parameter for gaussian distribution is invalid")
+		}
+	default:
+		return 0, 0, errors.New("too many parameters for gaussian distribution")
+	}
+	return mean, stddev, nil
+}
+
 // Ratio describes how likely certain operation is going to happen.
 //
 // For example the ratio represented by "fixed(1)/1" string says
@@ -197,11 +529,10 @@ type Ratio struct {
 // For example the "uniform(1..10)/10" ratio is parsed to the
 // following value:
 //
-//   &Ratio{
-//     Distribution: &Uniform{Min: 1, Max: 10},
-//     Value: 10,
-//   }
-//
+//	&Ratio{
+//	  Distribution: &Uniform{Min: 1, Max: 10},
+//	  Value: 10,
+//	}
 func ParseRatio(s string) (*Ratio, error) {
 	d, err := ParseDistribution(s)
 	if err != nil {
@@ -234,6 +565,26 @@ func (r *Ratio) Generate() int64 {
 	return r.Distribution.Generate()
 }
 
+// Inverted wraps a Ranged distribution and mirrors its samples around the
+// midpoint of its [Min, Max] interval: for a wrapped distribution with
+// CDF F, Generate returns Min+Max-x for every x the wrapped distribution
+// generates. This is the "~" prefix from cassandra-stress profiles, e.g.
+// "~exp(1..100)" favors values near the high end instead of the low one.
+type Inverted struct {
+	Ranged
+}
+
+// String implements the fmt.Stringer interface.
+func (i Inverted) String() string {
+	return fmt.Sprintf("~%s", i.Ranged)
+}
+
+// Generate implements the Distribution interface.
+func (i Inverted) Generate() int64 {
+	min, max := i.Bounds()
+	return min + max - i.Ranged.Generate()
+}
+
 // Fixed represents a fixed distribution, that always returns specified value.
 type Fixed struct {
 	Value int64
@@ -252,6 +603,12 @@ func (f Fixed) Generate() int64 {
 // Uniform represents a uniform distribution over specified [Min, Max] range.
 type Uniform struct {
 	Min, Max int64 // upper and lower bound of the distribution
+
+	// Rand, when set, is used instead of the package-level random source.
+	// This lets a caller give a Uniform its own, unshared *rand.Rand -
+	// e.g. one per worker goroutine, seeded via NewSource and SplitMix64 -
+	// for reproducible, contention-free generation.
+	Rand *rand.Rand
 }
 
 // String implements the fmt.Stringer interface.
@@ -261,7 +618,335 @@ func (u Uniform) String() string {
 
 // Generate implements the Distribution interface.
 func (u Uniform) Generate() int64 {
-	return u.Min + globalRand.Int63n(u.Max-u.Min)
+	return u.Min + rnd(u.Rand).Int63n(u.Max-u.Min)
+}
+
+// Bounds implements the Ranged interface.
+func (u Uniform) Bounds() (min, max int64) {
+	return u.Min, u.Max
+}
+
+// Gaussian represents a normal distribution over the specified [Min, Max]
+// range with the given Mean and StdDev. Values sampled outside of the
+// range are rejected and resampled, which is the same clipping behaviour
+// cassandra-stress's gaussian distribution uses.
+type Gaussian struct {
+	Min, Max int64
+	Mean     float64
+	StdDev   float64
+
+	// Rand, when set, is used instead of the package-level random source.
+	Rand *rand.Rand
+}
+
+// String implements the fmt.Stringer interface.
+func (g Gaussian) String() string {
+	return fmt.Sprintf("Gaussian(min=%d, max=%d, mean=%g, stddev=%g)", g.Min, g.Max, g.Mean, g.StdDev)
+}
+
+// maxRejectionAttempts bounds the rejection loops of Gaussian and Exp so
+// that a Mean/StdDev (or Lambda) inconsistent with [Min, Max] - however
+// it was constructed - can't spin the calling goroutine forever.
+const maxRejectionAttempts = 10000
+
+// Generate implements the Distribution interface.
+//
+// Sampling is done with NormFloat64, which itself is a ziggurat-method
+// generator over the standard normal distribution; the result is scaled
+// by StdDev, shifted by Mean and clipped into [Min, Max] by rejection.
+// If no sample lands in range within maxRejectionAttempts tries, Mean
+// clamped to [Min, Max] is returned instead of looping forever.
+func (g Gaussian) Generate() int64 {
+	r := rnd(g.Rand)
+	for i := 0; i < maxRejectionAttempts; i++ {
+		v := g.Mean + r.NormFloat64()*g.StdDev
+		if n := int64(math.Round(v)); n >= g.Min && n <= g.Max {
+			return n
+		}
+	}
+	return int64(math.Min(math.Max(g.Mean, float64(g.Min)), float64(g.Max)))
+}
+
+// Bounds implements the Ranged interface.
+func (g Gaussian) Bounds() (min, max int64) {
+	return g.Min, g.Max
+}
+
+// Exp represents an exponential distribution over the specified
+// [Min, Max] range with rate Lambda.
+type Exp struct {
+	Min, Max int64
+	Lambda   float64
+
+	// Rand, when set, is used instead of the package-level random source.
+	Rand *rand.Rand
+}
+
+// String implements the fmt.Stringer interface.
+func (e Exp) String() string {
+	return fmt.Sprintf("Exp(min=%d, max=%d, lambda=%g)", e.Min, e.Max, e.Lambda)
+}
+
+// Generate implements the Distribution interface.
+//
+// Sampling is done with ExpFloat64, a ziggurat-method generator over the
+// standard exponential distribution; the result is scaled by 1/Lambda,
+// shifted by Min and clipped into [Min, Max] by rejection. If no sample
+// lands in range within maxRejectionAttempts tries (e.g. a non-positive
+// Lambda), Min is returned instead of looping forever.
+func (e Exp) Generate() int64 {
+	r := rnd(e.Rand)
+	for i := 0; i < maxRejectionAttempts; i++ {
+		if n := e.Min + int64(math.Round(r.ExpFloat64()/e.Lambda)); n >= e.Min && n <= e.Max {
+			return n
+		}
+	}
+	return e.Min
+}
+
+// Bounds implements the Ranged interface.
+func (e Exp) Bounds() (min, max int64) {
+	return e.Min, e.Max
+}
+
+// Extreme represents a Fréchet-style extreme value distribution over the
+// specified [Min, Max] range. The smaller Shape is, the heavier the tail
+// and the more the generated values concentrate near Max.
+type Extreme struct {
+	Min, Max int64
+	Shape    float64
+
+	// Rand, when set, is used instead of the package-level random source.
+	Rand *rand.Rand
+}
+
+// String implements the fmt.Stringer interface.
+func (e Extreme) String() string {
+	return fmt.Sprintf("Extreme(min=%d, max=%d, shape=%g)", e.Min, e.Max, e.Shape)
+}
+
+// Generate implements the Distribution interface.
+func (e Extreme) Generate() int64 {
+	u := rnd(e.Rand).Float64()
+	v := float64(e.Min) + float64(e.Max-e.Min)*(1-math.Pow(u, 1/e.Shape))
+	return int64(v)
+}
+
+// Bounds implements the Ranged interface.
+func (e Extreme) Bounds() (min, max int64) {
+	return e.Min, e.Max
+}
+
+// Zipf represents a Zipfian (power-law) distribution over the specified
+// [Min, Max] range with skew parameter S: the larger S is, the more
+// disproportionately the lowest values in the range are favored. This
+// models partition-key access patterns where a small number of keys
+// receive most of the traffic.
+type Zipf struct {
+	Min, Max int64
+	S        float64
+
+	// Rand, when set, is used instead of the package-level random source.
+	Rand *rand.Rand
+}
+
+// String implements the fmt.Stringer interface.
+func (z Zipf) String() string {
+	return fmt.Sprintf("Zipf(min=%d, max=%d, s=%g)", z.Min, z.Max, z.S)
+}
+
+// Bounds implements the Ranged interface.
+func (z Zipf) Bounds() (min, max int64) {
+	return z.Min, z.Max
+}
+
+// Generate implements the Distribution interface.
+//
+// It uses the rejection-inversion method of Hörmann & Derflinger, which
+// samples in O(1) expected time regardless of S or the size of the
+// range - unlike building and sampling a cumulative distribution table.
+func (z Zipf) Generate() int64 {
+	n := float64(z.Max - z.Min + 1)
+	hIntegralX1 := zipfHIntegral(1.5, z.S) - 1
+	hIntegralN := zipfHIntegral(n+0.5, z.S)
+	spread := 2 - zipfHIntegralInverse(zipfHIntegral(2.5, z.S)-zipfH(2, z.S), z.S)
+
+	r := rnd(z.Rand)
+	for {
+		u := hIntegralN + r.Float64()*(hIntegralX1-hIntegralN)
+		x := zipfHIntegralInverse(u, z.S)
+		k := math.Floor(x + 0.5)
+		switch {
+		case k < 1:
+			k = 1
+		case k > n:
+			k = n
+		}
+		if k-x <= spread || u >= zipfHIntegral(k+0.5, z.S)-zipfH(k, z.S) {
+			return z.Min + int64(k) - 1
+		}
+	}
+}
+
+// zipfH, zipfHIntegral and zipfHIntegralInverse implement the "h",
+// integral of "h" and inverse of that integral functions from the
+// rejection-inversion method, with the helper1/helper2 substitutions
+// recommended by the paper to keep them numerically stable as s -> 1.
+func zipfH(x, s float64) float64 {
+	return math.Exp(-s * math.Log(x))
+}
+
+func zipfHIntegral(x, s float64) float64 {
+	logX := math.Log(x)
+	return zipfHelper2((1-s)*logX) * logX
+}
+
+func zipfHIntegralInverse(x, s float64) float64 {
+	t := x * (1 - s)
+	if t < -1 {
+		t = -1
+	}
+	return math.Exp(zipfHelper1(t) * x)
+}
+
+func zipfHelper1(x float64) float64 {
+	if math.Abs(x) > 1e-8 {
+		return math.Log1p(x) / x
+	}
+	return 1 - x*(0.5-x*(1.0/3-0.25*x))
+}
+
+func zipfHelper2(x float64) float64 {
+	if math.Abs(x) > 1e-8 {
+		return math.Expm1(x) / x
+	}
+	return 1 + x*0.5*(1+x/3*(1+0.25*x))
+}
+
+// Hotspot represents a uniform distribution over the specified
+// [Min, Max] range that sends HotTraffic fraction of accesses to the
+// "hot" sub-range [Min, Min+HotFraction*(Max-Min)] and the remainder
+// uniformly to the rest, e.g. "90% of traffic to 10% of keys".
+type Hotspot struct {
+	Min, Max    int64
+	HotFraction float64
+	HotTraffic  float64
+
+	// Rand, when set, is used instead of the package-level random source.
+	Rand *rand.Rand
+}
+
+// String implements the fmt.Stringer interface.
+func (h Hotspot) String() string {
+	return fmt.Sprintf("Hotspot(min=%d, max=%d, hot_fraction=%g, hot_traffic=%g)", h.Min, h.Max, h.HotFraction, h.HotTraffic)
+}
+
+// Bounds implements the Ranged interface.
+func (h Hotspot) Bounds() (min, max int64) {
+	return h.Min, h.Max
+}
+
+// Generate implements the Distribution interface.
+func (h Hotspot) Generate() int64 {
+	r := rnd(h.Rand)
+	hotMax := h.Min + int64(h.HotFraction*float64(h.Max-h.Min))
+	switch {
+	case hotMax < h.Min:
+		hotMax = h.Min
+	case hotMax > h.Max:
+		hotMax = h.Max
+	}
+	if r.Float64() < h.HotTraffic {
+		return h.Min + r.Int63n(hotMax-h.Min+1)
+	}
+	if hotMax >= h.Max {
+		return h.Max
+	}
+	return hotMax + 1 + r.Int63n(h.Max-hotMax)
+}
+
+// Weighted represents a weighted categorical distribution: each of the
+// values is returned with probability proportional to its weight. It is
+// backed by Vose's alias method, so Generate runs in O(1) regardless of
+// how many values it holds.
+type Weighted struct {
+	values []int64
+	prob   []float64
+	alias  []int
+
+	// Rand, when set, is used instead of the package-level random source.
+	Rand *rand.Rand
+}
+
+// NewWeighted builds a Weighted distribution returning one of values,
+// each with probability proportional to the corresponding entry in
+// weights. It panics if the two slices differ in length or are empty.
+func NewWeighted(values []int64, weights []float64) *Weighted {
+	if len(values) == 0 || len(values) != len(weights) {
+		panic("random: values and weights must be non-empty and of equal length")
+	}
+	n := len(weights)
+	w := &Weighted{
+		values: append([]int64(nil), values...),
+		prob:   make([]float64, n),
+		alias:  make([]int, n),
+	}
+
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, weight := range weights {
+		scaled[i] = float64(n) * weight / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		w.prob[s] = scaled[s]
+		w.alias[s] = l
+
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	for _, l := range large {
+		w.prob[l] = 1
+	}
+	for _, s := range small {
+		w.prob[s] = 1
+	}
+
+	return w
+}
+
+// String implements the fmt.Stringer interface.
+func (w *Weighted) String() string {
+	return fmt.Sprintf("Weighted(%d values)", len(w.values))
+}
+
+// Generate implements the Distribution interface.
+func (w *Weighted) Generate() int64 {
+	r := rnd(w.Rand)
+	i := r.Intn(len(w.values))
+	if r.Float64() < w.prob[i] {
+		return w.values[i]
+	}
+	return w.values[w.alias[i]]
 }
 
 func max(i, j int64) int64 {
@@ -0,0 +1,266 @@
+package random
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestParseDistribution(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    Distribution
+		wantErr bool
+	}{
+		{name: "fixed", input: "fixed(10)", want: &Fixed{Value: 10}},
+		{name: "fixed short syntax", input: "fixed(10K)", want: &Fixed{Value: 10000}},
+		{name: "fixed binary short syntax", input: "fixed(1Ki)", want: &Fixed{Value: 1024}},
+		{name: "fixed invalid", input: "fixed(x)", wantErr: true},
+
+		{name: "uniform", input: "uniform(1..100)", want: &Uniform{Min: 1, Max: 100}},
+		{name: "uniform short syntax", input: "uniform(1..1K)", want: &Uniform{Min: 1, Max: 1000}},
+		{name: "uniform min > max", input: "uniform(100..1)", wantErr: true},
+		{name: "uniform bad format", input: "uniform(1-100)", wantErr: true},
+
+		{name: "gaussian stdvrng", input: "gaussian(0..100,5)", want: &Gaussian{Min: 0, Max: 100, Mean: 50, StdDev: 20}},
+		{name: "gaussian mean,stddev", input: "gaussian(0..100,40,10)", want: &Gaussian{Min: 0, Max: 100, Mean: 40, StdDev: 10}},
+		{name: "gauss alias", input: "gauss(0..100)", want: &Gaussian{Min: 0, Max: 100, Mean: 50, StdDev: float64(100) / 6}},
+		{name: "gaussian short syntax", input: "gauss(1..1M,3)", want: &Gaussian{Min: 1, Max: 1000000, Mean: 500000.5, StdDev: float64(999999) / 3}},
+		{name: "gaussian too many params", input: "gaussian(0..100,1,2,3)", wantErr: true},
+
+		{name: "exp default lambda", input: "exp(0..100)", want: &Exp{Min: 0, Max: 100, Lambda: 1}},
+		{name: "exp with lambda", input: "exp(0..100,2.5)", want: &Exp{Min: 0, Max: 100, Lambda: 2.5}},
+
+		{name: "extreme", input: "extreme(0..100,1.5)", want: &Extreme{Min: 0, Max: 100, Shape: 1.5}},
+		{name: "extreme missing shape", input: "extreme(0..100)", wantErr: true},
+
+		{name: "zipf", input: "zipf(0..100,1.2)", want: &Zipf{Min: 0, Max: 100, S: 1.2}},
+		{name: "zipf missing s", input: "zipf(0..100)", wantErr: true},
+
+		{name: "hotspot", input: "hotspot(0..100,0.1,0.9)", want: &Hotspot{Min: 0, Max: 100, HotFraction: 0.1, HotTraffic: 0.9}},
+		{name: "hotspot missing params", input: "hotspot(0..100,0.1)", wantErr: true},
+
+		{name: "weighted", input: "weighted([1:1,2:3])", want: NewWeighted([]int64{1, 2}, []float64{1, 3})},
+		{name: "weighted bad brackets", input: "weighted(1:1,2:3)", wantErr: true},
+		{name: "weighted bad entry", input: "weighted([1])", wantErr: true},
+
+		{name: "inverted uniform", input: "~uniform(0..100)", want: Inverted{Ranged: &Uniform{Min: 0, Max: 100}}},
+		{name: "inverted weighted unsupported", input: "~weighted([1:1,2:1])", wantErr: true},
+
+		{name: "missing '('", input: "uniform 0..100)", wantErr: true},
+		{name: "missing ')'", input: "uniform(0..100", wantErr: true},
+		{name: "unsupported type", input: "bogus(0..100)", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseDistribution(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDistribution(%q) = %v, want error", tc.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDistribution(%q) returned error: %v", tc.input, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ParseDistribution(%q) = %#v, want %#v", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGaussianGenerate(t *testing.T) {
+	g := Gaussian{Min: 0, Max: 100, Mean: 50, StdDev: 10}
+	const n = 20000
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		v := g.Generate()
+		if v < g.Min || v > g.Max {
+			t.Fatalf("Generate() = %d, want within [%d, %d]", v, g.Min, g.Max)
+		}
+		sum += float64(v)
+	}
+	if mean := sum / n; mean < 45 || mean > 55 {
+		t.Fatalf("sample mean = %g, want close to %g", mean, g.Mean)
+	}
+}
+
+func TestExpGenerate(t *testing.T) {
+	e := Exp{Min: 0, Max: 1000, Lambda: 0.1}
+	const n = 20000
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		v := e.Generate()
+		if v < e.Min || v > e.Max {
+			t.Fatalf("Generate() = %d, want within [%d, %d]", v, e.Min, e.Max)
+		}
+		sum += float64(v)
+	}
+	// The mean of an unclipped Exp(lambda) is 1/lambda; clipping to
+	// [Min, Max] should leave it in the same ballpark.
+	if mean := sum / n; mean < 6 || mean > 14 {
+		t.Fatalf("sample mean = %g, want close to %g", mean, 1/e.Lambda)
+	}
+}
+
+func TestExtremeGenerate(t *testing.T) {
+	e := Extreme{Min: 0, Max: 100, Shape: 0.5}
+	const n = 20000
+	aboveMid := 0
+	for i := 0; i < n; i++ {
+		v := e.Generate()
+		if v < e.Min || v > e.Max {
+			t.Fatalf("Generate() = %d, want within [%d, %d]", v, e.Min, e.Max)
+		}
+		if v > (e.Min+e.Max)/2 {
+			aboveMid++
+		}
+	}
+	// Extreme concentrates values near Max, so most samples should land
+	// in the upper half of the range.
+	if aboveMid < n*3/5 {
+		t.Fatalf("only %d/%d samples above the midpoint, want a clear majority", aboveMid, n)
+	}
+}
+
+func TestWeightedGenerate(t *testing.T) {
+	w := NewWeighted([]int64{1, 2, 3}, []float64{1, 1, 8})
+	const n = 20000
+	counts := map[int64]int{}
+	for i := 0; i < n; i++ {
+		counts[w.Generate()]++
+	}
+	if got := float64(counts[3]) / n; got < 0.75 || got > 0.85 {
+		t.Fatalf("value 3 sampled %.2f%% of the time, want close to 80%%", got*100)
+	}
+}
+
+func TestZipfGenerate(t *testing.T) {
+	z := Zipf{Min: 0, Max: 100, S: 1.5}
+	const n = 20000
+	belowMid := 0
+	for i := 0; i < n; i++ {
+		v := z.Generate()
+		if v < z.Min || v > z.Max {
+			t.Fatalf("Generate() = %d, want within [%d, %d]", v, z.Min, z.Max)
+		}
+		if v < (z.Min+z.Max)/2 {
+			belowMid++
+		}
+	}
+	// Zipf concentrates values near Min, so most samples should land in
+	// the lower half of the range.
+	if belowMid < n*3/4 {
+		t.Fatalf("only %d/%d samples below the midpoint, want a clear majority", belowMid, n)
+	}
+}
+
+func TestHotspotGenerate(t *testing.T) {
+	h := Hotspot{Min: 0, Max: 100, HotFraction: 0.1, HotTraffic: 0.9}
+	const n = 20000
+	hot := 0
+	hotMax := h.Min + int64(h.HotFraction*float64(h.Max-h.Min))
+	for i := 0; i < n; i++ {
+		v := h.Generate()
+		if v < h.Min || v > h.Max {
+			t.Fatalf("Generate() = %d, want within [%d, %d]", v, h.Min, h.Max)
+		}
+		if v <= hotMax {
+			hot++
+		}
+	}
+	// 90% of traffic should land in the 10%-sized hot sub-range.
+	if got := float64(hot) / n; got < 0.8 || got > 1 {
+		t.Fatalf("hot sub-range sampled %.2f%% of the time, want close to 90%%", got*100)
+	}
+}
+
+func TestHotspotGenerateBoundaryFractions(t *testing.T) {
+	allHot := Hotspot{Min: 0, Max: 100, HotFraction: 1, HotTraffic: 0.9}
+	for i := 0; i < 1000; i++ {
+		if v := allHot.Generate(); v < allHot.Min || v > allHot.Max {
+			t.Fatalf("Generate() = %d, want within [%d, %d]", v, allHot.Min, allHot.Max)
+		}
+	}
+
+	noHot := Hotspot{Min: 0, Max: 100, HotFraction: 0, HotTraffic: 0.9}
+	for i := 0; i < 1000; i++ {
+		if v := noHot.Generate(); v < noHot.Min || v > noHot.Max {
+			t.Fatalf("Generate() = %d, want within [%d, %d]", v, noHot.Min, noHot.Max)
+		}
+	}
+}
+
+func TestParseHotspotInvalidParams(t *testing.T) {
+	cases := []string{
+		"hotspot(0..100,-0.5,0.9)",
+		"hotspot(0..100,1.5,0.9)",
+		"hotspot(0..100,0.1,-0.1)",
+		"hotspot(0..100,0.1,1.1)",
+	}
+	for _, input := range cases {
+		if _, err := ParseDistribution(input); err == nil {
+			t.Fatalf("ParseDistribution(%q) = nil error, want error", input)
+		}
+	}
+}
+
+func TestNewSourceDeterministic(t *testing.T) {
+	r1 := rand.New(NewSource(42))
+	r2 := rand.New(NewSource(42))
+	for i := 0; i < 1000; i++ {
+		if a, b := r1.Int63(), r2.Int63(); a != b {
+			t.Fatalf("NewSource(42) sequences diverged at draw %d: %d != %d", i, a, b)
+		}
+	}
+}
+
+func TestSplitMix64DistinctPerWorker(t *testing.T) {
+	const master = 1
+	seen := map[uint64]bool{}
+	for worker := uint64(0); worker < 64; worker++ {
+		sub := SplitMix64(master, worker)
+		if seen[sub] {
+			t.Fatalf("SplitMix64(%d, %d) collided with a previous worker's sub-seed", master, worker)
+		}
+		seen[sub] = true
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    uint64
+		wantErr bool
+	}{
+		{input: "0", want: 0},
+		{input: "42", want: 42},
+		{input: "10K", want: 10000},
+		{input: "10M", want: 10000000},
+		{input: "1B", want: 1000000000},
+		{input: "1Ki", want: 1024},
+		{input: "1Mi", want: 1024 * 1024},
+		{input: "1Gi", want: 1024 * 1024 * 1024},
+		{input: "", wantErr: true},
+		{input: "1x", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := parseSize(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseSize(%q) = %d, want error", tc.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSize(%q) returned error: %v", tc.input, err)
+			}
+			if got != tc.want {
+				t.Fatalf("parseSize(%q) = %d, want %d", tc.input, got, tc.want)
+			}
+		})
+	}
+}